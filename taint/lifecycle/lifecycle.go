@@ -0,0 +1,161 @@
+// Package lifecycle implements a small node-lifecycle controller that keeps driver-owned Node
+// conditions and taints bidirectionally in sync, inspired by the taint-manager pattern in
+// kube-controller-manager's node lifecycle controller.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"github.com/DataDog/csi-lib-utils/taint"
+)
+
+// pollInterval is the backstop reconcile period, in case the informer misses an update (for
+// example a condition transition applied by the driver's own client without going through the
+// watched object).
+const pollInterval = 30 * time.Second
+
+// Binding ties a Node condition to the taint that must be present whenever the condition is
+// False, and absent whenever it is True. A NoExecute effect cooperates with existing pod
+// tolerations, so pods tolerating e.g. "{driver}/agent-not-ready:NoExecute" keep running across
+// driver restarts instead of being evicted the moment the taint reappears.
+type Binding struct {
+	ConditionType v1.NodeConditionType
+	TaintKey      string
+	Effect        v1.TaintEffect
+}
+
+// Controller observes a single node via a shared informer and enforces every registered Binding:
+// when the driver sets ConditionType=False it applies the bound taint, and when the condition
+// flips back to True it removes the taint through the same JSON-patch path taint.TaintManager
+// uses. It replaces the one-shot taint.RemoveTaintInBackground for drivers that need continuous
+// enforcement, while that function remains a fine thin wrapper for drivers that only need a
+// single startup removal.
+type Controller struct {
+	client   kubernetes.Interface
+	nodeName string
+	bindings []Binding
+}
+
+// New returns a Controller for nodeName. Register bindings with Bind before calling Run.
+func New(client kubernetes.Interface, nodeName string) *Controller {
+	return &Controller{client: client, nodeName: nodeName}
+}
+
+// Bind registers a (condition, taint key, effect) triple the controller should keep in sync. It
+// returns the Controller so calls can be chained, e.g.
+// lifecycle.New(client, nodeName).Bind(v1.NodeConditionType("FooReady"), "foo.csi.driver.io/agent-not-ready", v1.TaintEffectNoExecute).Run(ctx).
+func (c *Controller) Bind(conditionType v1.NodeConditionType, taintKey string, effect v1.TaintEffect) *Controller {
+	c.bindings = append(c.bindings, Binding{ConditionType: conditionType, TaintKey: taintKey, Effect: effect})
+	return c
+}
+
+// Run starts the controller and blocks until ctx is done, reconciling every Binding whenever the
+// node changes and once per pollInterval as a backstop.
+func (c *Controller) Run(ctx context.Context) error {
+	logger := klog.FromContext(ctx)
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", c.nodeName).String()
+
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return c.client.CoreV1().Nodes().List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return c.client.CoreV1().Nodes().Watch(ctx, options)
+		},
+	}
+
+	reconcileCh := make(chan struct{}, 1)
+	trigger := func() {
+		select {
+		case reconcileCh <- struct{}{}:
+		default:
+		}
+	}
+
+	_, informer := cache.NewInformer(lw, &v1.Node{}, 0, cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { trigger() },
+		UpdateFunc: func(oldObj, newObj interface{}) { trigger() },
+	})
+
+	go informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return fmt.Errorf("failed to sync node informer for %s", c.nodeName)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	trigger()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			trigger()
+		case <-reconcileCh:
+			if err := c.reconcile(ctx); err != nil {
+				logger.Error(err, "Unexpected failure reconciling node taints against conditions", "node", c.nodeName)
+			}
+		}
+	}
+}
+
+// reconcile ensures each Binding's taint <-> condition invariant holds for the current state of
+// the node.
+func (c *Controller) reconcile(ctx context.Context) error {
+	node, err := c.client.CoreV1().Nodes().Get(ctx, c.nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	manager := taint.NewTaintManager(c.client, c.nodeName)
+	var toAdd, toRemove []v1.Taint
+
+	for _, b := range c.bindings {
+		t := v1.Taint{Key: b.TaintKey, Effect: b.Effect}
+		present := taint.TaintPresent(node.Spec.Taints, t)
+		switch {
+		case conditionIsFalse(node, b.ConditionType) && !present:
+			toAdd = append(toAdd, t)
+		case !conditionIsFalse(node, b.ConditionType) && present:
+			toRemove = append(toRemove, t)
+		}
+	}
+
+	if len(toRemove) > 0 {
+		if err := manager.RemoveTaints(ctx, toRemove); err != nil {
+			return err
+		}
+	}
+	if len(toAdd) > 0 {
+		if err := manager.AddTaints(ctx, toAdd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conditionIsFalse reports whether conditionType is reported False on node. A condition the
+// driver has not reported yet is treated the same as False, so the bound taint is applied until
+// the driver proves readiness.
+func conditionIsFalse(node *v1.Node, conditionType v1.NodeConditionType) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == conditionType {
+			return cond.Status == v1.ConditionFalse
+		}
+	}
+	return true
+}
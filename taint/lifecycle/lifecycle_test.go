@@ -0,0 +1,77 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stesting "k8s.io/client-go/testing"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	conditionType = v1.NodeConditionType("FakeDriverReady")
+	taintKey      = "fake.csi.driver.io/agent-not-ready"
+)
+
+func taintPresent(t *testing.T, client *fake.Clientset, nodeName string) bool {
+	t.Helper()
+	node, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	for _, tt := range node.Spec.Taints {
+		if tt.Key == taintKey && tt.Effect == v1.TaintEffectNoExecute {
+			return true
+		}
+	}
+	return false
+}
+
+func TestControllerSyncsTaintToCondition(t *testing.T) {
+	nodeName := "node"
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+		Status: v1.NodeStatus{
+			Conditions: []v1.NodeCondition{{Type: conditionType, Status: v1.ConditionFalse}},
+		},
+	}
+
+	client := fake.NewSimpleClientset(node)
+	fakeWatch := watch.NewFake()
+	client.PrependWatchReactor("nodes", k8stesting.DefaultWatchReactor(fakeWatch, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	controller := New(client, nodeName).Bind(conditionType, taintKey, v1.TaintEffectNoExecute)
+	go func() {
+		_ = controller.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		return taintPresent(t, client, nodeName)
+	}, time.Second, 10*time.Millisecond, "taint should be applied while the condition is False")
+
+	// Start from the node as the controller left it, so the taint is still present and only the
+	// controller can remove it.
+	node, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	node.Status.Conditions[0].Status = v1.ConditionTrue
+	_, err = client.CoreV1().Nodes().Update(context.Background(), node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	fakeWatch.Modify(node)
+
+	require.Eventually(t, func() bool {
+		return !taintPresent(t, client, nodeName)
+	}, time.Second, 10*time.Millisecond, "taint should be removed once the condition is True")
+}
+
+func TestConditionIsFalseDefaultsTrueForUnreportedCondition(t *testing.T) {
+	node := &v1.Node{}
+	assert.True(t, conditionIsFalse(node, conditionType))
+}
@@ -0,0 +1,133 @@
+package taint
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller"
+)
+
+// AddTaintInBackground is a goroutine that retries adding taint to nodeName with exponential backoff.
+// Unlike RemoveTaintInBackground, it is not tied to the driverName/agent-not-ready key: callers can
+// apply any taint they want enforced at driver startup, such as a driver-specific start-up taint.
+func AddTaintInBackground(ctx context.Context, k8sClient kubernetes.Interface, nodeName string, taint v1.Taint, backoff wait.Backoff) {
+	logger := klog.FromContext(ctx)
+	manager := NewTaintManager(k8sClient, nodeName)
+	backoffErr := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		err := manager.AddTaints(ctx, []v1.Taint{taint})
+		if err != nil {
+			logger.Error(err, "Unexpected failure when attempting to add node taint(s)")
+			return false, nil
+		}
+		return true, nil
+	})
+
+	if backoffErr != nil {
+		logger.Error(backoffErr, "Retries exhausted, giving up attempting to add node taint(s)")
+	}
+}
+
+// TaintManager adds, removes, and refreshes arbitrary taints on a single node, going through the
+// same controller.PatchNodeTaints path as Remover.Remove. It identifies taints by key+effect rather than the
+// driverName/agent-not-ready convention used elsewhere in this package, so it can be reused for
+// any driver-owned taint.
+type TaintManager struct {
+	client   kubernetes.Interface
+	nodeName string
+}
+
+// NewTaintManager returns a TaintManager for the given node.
+func NewTaintManager(client kubernetes.Interface, nodeName string) *TaintManager {
+	return &TaintManager{
+		client:   client,
+		nodeName: nodeName,
+	}
+}
+
+// AddTaints adds each of the given taints to the node, skipping any taint that already has a
+// match by key+effect. It does not update the value of an existing taint; use Refresh for that.
+func (m *TaintManager) AddTaints(ctx context.Context, taints []v1.Taint) error {
+	return m.patch(ctx, func(existing []v1.Taint) []v1.Taint {
+		newTaints := existing
+		for _, t := range taints {
+			if taintExistsByKeyEffect(newTaints, t) {
+				continue
+			}
+			newTaints = append(newTaints, t)
+		}
+		return newTaints
+	})
+}
+
+// RemoveTaints removes any taint on the node that matches one of the given taints by key+effect.
+func (m *TaintManager) RemoveTaints(ctx context.Context, taints []v1.Taint) error {
+	return m.patch(ctx, func(existing []v1.Taint) []v1.Taint {
+		newTaints := make([]v1.Taint, 0, len(existing))
+		for _, t := range existing {
+			if taintExistsByKeyEffect(taints, t) {
+				continue
+			}
+			newTaints = append(newTaints, t)
+		}
+		return newTaints
+	})
+}
+
+// Refresh removes any existing taint that matches one of the given taints by key+effect and
+// re-adds it, so the stored value is brought up to date with the caller's desired taint.
+func (m *TaintManager) Refresh(ctx context.Context, taints []v1.Taint) error {
+	return m.patch(ctx, func(existing []v1.Taint) []v1.Taint {
+		newTaints := make([]v1.Taint, 0, len(existing)+len(taints))
+		for _, t := range existing {
+			if taintExistsByKeyEffect(taints, t) {
+				continue
+			}
+			newTaints = append(newTaints, t)
+		}
+		return append(newTaints, taints...)
+	})
+}
+
+// patch fetches the node, applies mutate to its current taints, and patches the result back
+// through controller.PatchNodeTaints. The patch is sent even when mutate leaves the taints
+// unchanged.
+func (m *TaintManager) patch(ctx context.Context, mutate func([]v1.Taint) []v1.Taint) error {
+	logger := klog.FromContext(ctx)
+	node, err := m.client.CoreV1().Nodes().Get(ctx, m.nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	newNode := node.DeepCopy()
+	newNode.Spec.Taints = mutate(node.Spec.Taints)
+
+	if err := controller.PatchNodeTaints(ctx, m.client, m.nodeName, node, newNode); err != nil {
+		return err
+	}
+	logger.V(2).Info("patched node taints", "node", m.nodeName, "taints", newNode.Spec.Taints)
+	return nil
+}
+
+// TaintPresent reports whether taints already contains an entry matching t's key and effect,
+// ignoring value. It exposes the same dedupe check AddTaints/RemoveTaints use internally so
+// callers driving their own reconcile loop (e.g. taint/lifecycle) can skip a patch entirely when
+// the node is already in the desired state.
+func TaintPresent(taints []v1.Taint, t v1.Taint) bool {
+	return taintExistsByKeyEffect(taints, t)
+}
+
+// taintExistsByKeyEffect reports whether taints contains an entry matching t's key and effect,
+// ignoring value. This mirrors the dedupe semantics startup-taint flags expect: a taint is
+// considered "already applied" regardless of its value.
+func taintExistsByKeyEffect(taints []v1.Taint, t v1.Taint) bool {
+	for _, existing := range taints {
+		if existing.Key == t.Key && existing.Effect == t.Effect {
+			return true
+		}
+	}
+	return false
+}
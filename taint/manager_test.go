@@ -0,0 +1,83 @@
+package taint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTaintManagerAddTaints(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	nodeName := "node"
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+	}
+	_, err := client.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	manager := NewTaintManager(client, nodeName)
+	startupTaint := v1.Taint{Key: "fake.csi.driver.io/start-up", Effect: v1.TaintEffectNoSchedule}
+
+	require.NoError(t, manager.AddTaints(context.Background(), []v1.Taint{startupTaint}))
+
+	got, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []v1.Taint{startupTaint}, got.Spec.Taints)
+
+	// Adding the same key+effect again should not duplicate the taint.
+	require.NoError(t, manager.AddTaints(context.Background(), []v1.Taint{startupTaint}))
+	got, err = client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []v1.Taint{startupTaint}, got.Spec.Taints)
+}
+
+func TestTaintManagerRemoveTaints(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	nodeName := "node"
+	startupTaint := v1.Taint{Key: "fake.csi.driver.io/start-up", Effect: v1.TaintEffectNoSchedule}
+	otherTaint := v1.Taint{Key: "other", Effect: v1.TaintEffectNoSchedule}
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+		Spec:       v1.NodeSpec{Taints: []v1.Taint{startupTaint, otherTaint}},
+	}
+	_, err := client.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	manager := NewTaintManager(client, nodeName)
+	require.NoError(t, manager.RemoveTaints(context.Background(), []v1.Taint{startupTaint}))
+
+	got, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []v1.Taint{otherTaint}, got.Spec.Taints)
+}
+
+func TestTaintManagerRefresh(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	nodeName := "node"
+	staleTaint := v1.Taint{Key: "fake.csi.driver.io/start-up", Effect: v1.TaintEffectNoSchedule, Value: "stale"}
+	freshTaint := v1.Taint{Key: "fake.csi.driver.io/start-up", Effect: v1.TaintEffectNoSchedule, Value: "fresh"}
+	otherTaint := v1.Taint{Key: "other", Effect: v1.TaintEffectNoSchedule}
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+		Spec:       v1.NodeSpec{Taints: []v1.Taint{staleTaint, otherTaint}},
+	}
+	_, err := client.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	manager := NewTaintManager(client, nodeName)
+	require.NoError(t, manager.Refresh(context.Background(), []v1.Taint{freshTaint}))
+
+	got, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	// Refresh must replace the stale value rather than leaving it alongside the fresh one, which
+	// is the one behavior AddTaints (dedupes by key+effect and leaves the value alone) can't do.
+	assert.ElementsMatch(t, []v1.Taint{otherTaint, freshTaint}, got.Spec.Taints)
+}
@@ -0,0 +1,50 @@
+package taint
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// removalMetrics holds the collectors registered against a single prometheus.Registerer. Each
+// registerer gets its own pair of collectors, so two drivers sharing a process but using
+// independent registries never observe each other's counts.
+type removalMetrics struct {
+	attemptsTotal   *prometheus.CounterVec
+	durationSeconds *prometheus.HistogramVec
+}
+
+var (
+	metricsMu    sync.Mutex
+	metricsByReg = map[prometheus.Registerer]*removalMetrics{}
+)
+
+// registerMetrics returns the removalMetrics registered with registerer, creating and registering
+// a fresh pair of collectors the first time registerer is seen. It returns nil if registerer is
+// nil, in which case callers should skip recording metrics entirely.
+func registerMetrics(registerer prometheus.Registerer) *removalMetrics {
+	if registerer == nil {
+		return nil
+	}
+
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+
+	if m, ok := metricsByReg[registerer]; ok {
+		return m
+	}
+
+	m := &removalMetrics{
+		attemptsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "csi_taint_removal_attempts_total",
+			Help: "Total number of attempts to remove the agent-not-ready taint, labeled by driver and result (success, failure)",
+		}, []string{"driver", "result"}),
+		durationSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "csi_taint_removal_duration_seconds",
+			Help: "Time from the start of a RemoveTaintInBackground backoff loop to its success or exhaustion, labeled by driver",
+		}, []string{"driver"}),
+	}
+	registerer.MustRegister(m.attemptsTotal, m.durationSeconds)
+	metricsByReg[registerer] = m
+	return m
+}
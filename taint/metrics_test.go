@@ -0,0 +1,80 @@
+package taint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+)
+
+func TestRemoveTaintInBackgroundEmitsEventAndMetric(t *testing.T) {
+	driverName := "metrics-test-driver.csi.driver.io"
+	nodeName := "node"
+	startupTaint := v1.Taint{Key: driverName + AgentNotReadyNodeTaintKeySuffix}
+
+	client := fake.NewSimpleClientset(
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Spec:       v1.NodeSpec{Taints: []v1.Taint{startupTaint}},
+		},
+		&storagev1.CSINode{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Spec: storagev1.CSINodeSpec{
+				Drivers: []storagev1.CSINodeDriver{
+					{Name: driverName, Allocatable: &storagev1.VolumeNodeResources{Count: int32Ptr(1)}},
+				},
+			},
+		},
+	)
+
+	recorder := events.NewFakeRecorder(1)
+	registry := prometheus.NewRegistry()
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+	RemoveTaintInBackground(context.Background(), client, nodeName, driverName, recorder, registry, backoff)
+
+	select {
+	case e := <-recorder.Events:
+		assert.Contains(t, e, "TaintRemoved")
+	default:
+		t.Fatal("expected a TaintRemoved event to be recorded")
+	}
+
+	metricFamilies, err := registry.Gather()
+	require.NoError(t, err)
+
+	var found bool
+	for _, mf := range metricFamilies {
+		if mf.GetName() != "csi_taint_removal_attempts_total" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			if labelValue(m, "result") == "success" && labelValue(m, "driver") == driverName {
+				found = true
+				assert.Equal(t, float64(1), m.GetCounter().GetValue())
+			}
+		}
+	}
+	assert.True(t, found, "expected a success counter sample for driver %s", driverName)
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+func int32Ptr(v int32) *int32 { return &v }
@@ -0,0 +1,99 @@
+package taint
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+)
+
+// outOfServiceTaint returns the standard node.kubernetes.io/out-of-service taint that the
+// kube-controller-manager node lifecycle controller looks for to trigger force-detach of volumes
+// and force-delete of pods. See
+// https://kubernetes.io/docs/concepts/architecture/nodes/#out-of-service-taint
+func outOfServiceTaint() v1.Taint {
+	return v1.Taint{
+		Key:    v1.TaintNodeOutOfService,
+		Effect: v1.TaintEffectNoExecute,
+	}
+}
+
+// ApplyOutOfServiceTaint applies the out-of-service taint to nodeName, recording an
+// "OutOfServiceTaintApplied" event on the Node with reason explaining why (e.g. "shutdown",
+// "instance-terminated"). It is a no-op, and emits no event, if the taint is already present.
+// Components that detect a node is permanently gone - for example because the cloud instance was
+// shut down but kubelet never reported NotReady - use this to trigger volume detach and pod
+// eviction, the pattern used by cloud-provider-azure.
+func ApplyOutOfServiceTaint(ctx context.Context, client kubernetes.Interface, nodeName, reason string, recorder events.EventRecorder, backoff wait.Backoff) error {
+	logger := klog.FromContext(ctx)
+	taint := outOfServiceTaint()
+	manager := NewTaintManager(client, nodeName)
+
+	return wait.ExponentialBackoff(backoff, func() (bool, error) {
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			logger.Error(err, "Unexpected failure getting node before applying out-of-service taint")
+			return false, nil
+		}
+
+		if taintExistsByKeyEffect(node.Spec.Taints, taint) {
+			return true, nil
+		}
+
+		if err := manager.AddTaints(ctx, []v1.Taint{taint}); err != nil {
+			logger.Error(err, "Unexpected failure applying out-of-service taint")
+			return false, nil
+		}
+
+		if recorder != nil {
+			recorder.Eventf(node, nil, v1.EventTypeNormal, "OutOfServiceTaintApplied", "ApplyOutOfServiceTaint", "Applied out-of-service taint: %s", reason)
+		}
+		return true, nil
+	})
+}
+
+// RemoveOutOfServiceTaint removes the out-of-service taint from nodeName, recording an
+// "OutOfServiceTaintRemoved" event on the Node. It is a no-op, and emits no event, if the taint is
+// not present.
+func RemoveOutOfServiceTaint(ctx context.Context, client kubernetes.Interface, nodeName string, recorder events.EventRecorder, backoff wait.Backoff) error {
+	logger := klog.FromContext(ctx)
+	taint := outOfServiceTaint()
+	manager := NewTaintManager(client, nodeName)
+
+	return wait.ExponentialBackoff(backoff, func() (bool, error) {
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			logger.Error(err, "Unexpected failure getting node before removing out-of-service taint")
+			return false, nil
+		}
+
+		if !taintExistsByKeyEffect(node.Spec.Taints, taint) {
+			return true, nil
+		}
+
+		if err := manager.RemoveTaints(ctx, []v1.Taint{taint}); err != nil {
+			logger.Error(err, "Unexpected failure removing out-of-service taint")
+			return false, nil
+		}
+
+		if recorder != nil {
+			recorder.Eventf(node, nil, v1.EventTypeNormal, "OutOfServiceTaintRemoved", "RemoveOutOfServiceTaint", "Removed out-of-service taint")
+		}
+		return true, nil
+	})
+}
+
+// ReconcileOutOfServiceTaint is a convenience wrapper safe to call from a reconcile loop: it
+// applies the out-of-service taint when desired is true and removes it when desired is false,
+// guarding against double-apply/double-remove the same way ApplyOutOfServiceTaint and
+// RemoveOutOfServiceTaint do individually.
+func ReconcileOutOfServiceTaint(ctx context.Context, client kubernetes.Interface, nodeName string, desired bool, reason string, recorder events.EventRecorder, backoff wait.Backoff) error {
+	if desired {
+		return ApplyOutOfServiceTaint(ctx, client, nodeName, reason, recorder, backoff)
+	}
+	return RemoveOutOfServiceTaint(ctx, client, nodeName, recorder, backoff)
+}
@@ -0,0 +1,61 @@
+package taint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+)
+
+func TestApplyAndRemoveOutOfServiceTaint(t *testing.T) {
+	nodeName := "node"
+	client := fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+	})
+	recorder := events.NewFakeRecorder(1)
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+	require.NoError(t, ApplyOutOfServiceTaint(context.Background(), client, nodeName, "shutdown", recorder, backoff))
+
+	node, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, taintExistsByKeyEffect(node.Spec.Taints, outOfServiceTaint()))
+	require.Len(t, recorder.Events, 1)
+	<-recorder.Events
+
+	// Re-applying is a no-op and must not emit another event.
+	require.NoError(t, ApplyOutOfServiceTaint(context.Background(), client, nodeName, "shutdown", recorder, backoff))
+	assert.Len(t, recorder.Events, 0)
+
+	require.NoError(t, RemoveOutOfServiceTaint(context.Background(), client, nodeName, recorder, backoff))
+	node, err = client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, taintExistsByKeyEffect(node.Spec.Taints, outOfServiceTaint()))
+	require.Len(t, recorder.Events, 1)
+	<-recorder.Events
+}
+
+func TestReconcileOutOfServiceTaint(t *testing.T) {
+	nodeName := "node"
+	client := fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+	})
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+
+	require.NoError(t, ReconcileOutOfServiceTaint(context.Background(), client, nodeName, true, "instance-terminated", nil, backoff))
+	node, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, taintExistsByKeyEffect(node.Spec.Taints, outOfServiceTaint()))
+
+	require.NoError(t, ReconcileOutOfServiceTaint(context.Background(), client, nodeName, false, "", nil, backoff))
+	node, err = client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, taintExistsByKeyEffect(node.Spec.Taints, outOfServiceTaint()))
+}
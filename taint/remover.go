@@ -0,0 +1,120 @@
+package taint
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/controller"
+	taintutils "k8s.io/kubernetes/pkg/util/taints"
+)
+
+// ReadinessCheck is a predicate that must pass before a Remover will remove the
+// driverName/agent-not-ready taint. It receives the same context the Remover was called with.
+type ReadinessCheck func(ctx context.Context) error
+
+// Remover removes the driverName/agent-not-ready taint from a node once every configured
+// ReadinessCheck passes within a single call to Remove. This generalizes the hard-coded
+// checkAllocatable gate so drivers that don't use CSINode Allocatable as their readiness signal -
+// for example ephemeral-only drivers, or ones gated on a gRPC Probe() RPC - can compose their own
+// readiness definition.
+type Remover struct {
+	client     kubernetes.Interface
+	nodeName   string
+	driverName string
+	checks     []ReadinessCheck
+}
+
+// RemoverOption configures a Remover built by NewRemover.
+type RemoverOption func(*Remover)
+
+// WithAllocatableCheck adds the original readiness signal: the node's CSINode Allocatable count
+// must be set for driverName. NewRemover applies this by default when no options are given, so
+// RemoveTaintInBackground keeps its historical behavior.
+func WithAllocatableCheck() RemoverOption {
+	return func(r *Remover) {
+		r.checks = append(r.checks, func(ctx context.Context) error {
+			return checkAllocatable(ctx, r.client, r.nodeName, r.driverName)
+		})
+	}
+}
+
+// WithCSINodeDriverPresent adds a readiness check that only requires driverName to have
+// registered itself in the node's CSINode object, without requiring Allocatable to be set. This
+// suits ephemeral-only drivers that never report Allocatable.
+func WithCSINodeDriverPresent() RemoverOption {
+	return func(r *Remover) {
+		r.checks = append(r.checks, func(ctx context.Context) error {
+			return checkCSINodeDriverPresent(ctx, r.client, r.nodeName, r.driverName)
+		})
+	}
+}
+
+// WithCustomCheck adds an arbitrary readiness predicate, such as confirmation that
+// NodePublishVolume has succeeded once or that a mount helper daemon is reachable.
+func WithCustomCheck(fn ReadinessCheck) RemoverOption {
+	return func(r *Remover) {
+		r.checks = append(r.checks, fn)
+	}
+}
+
+// NewRemover builds a Remover for nodeName/driverName. If no options are given it behaves like
+// the historical removeNotReadyTaint and applies WithAllocatableCheck().
+func NewRemover(client kubernetes.Interface, nodeName, driverName string, opts ...RemoverOption) *Remover {
+	r := &Remover{client: client, nodeName: nodeName, driverName: driverName}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if len(r.checks) == 0 {
+		WithAllocatableCheck()(r)
+	}
+	return r
+}
+
+// Remove runs every configured ReadinessCheck in order; if all of them pass, it removes the
+// driverName/agent-not-ready taint from the node. Any single failing check aborts the attempt so
+// the caller's backoff loop retries from the start.
+func (r *Remover) Remove(ctx context.Context) error {
+	logger := klog.FromContext(ctx)
+	for _, check := range r.checks {
+		if err := check(ctx); err != nil {
+			return err
+		}
+	}
+
+	node, err := r.client.CoreV1().Nodes().Get(ctx, r.nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	taintKeyToRemove := r.driverName + AgentNotReadyNodeTaintKeySuffix
+
+	logger.V(2).Info("removing taint", "key", taintKeyToRemove, "node", r.nodeName)
+
+	// We cannot use controller.RemoveTaintOffNode as it matches against effect as well
+	newTaints, _ := taintutils.DeleteTaintsByKey(node.Spec.Taints, taintKeyToRemove)
+	newNode := node.DeepCopy()
+	newNode.Spec.Taints = newTaints
+	if err := controller.PatchNodeTaints(ctx, r.client, r.nodeName, node, newNode); err != nil {
+		return err
+	}
+	logger.V(2).Info("removed taint successfully", "key", taintKeyToRemove, "node", r.nodeName)
+	return nil
+}
+
+func checkCSINodeDriverPresent(ctx context.Context, clientset kubernetes.Interface, nodeName, driverName string) error {
+	csiNode, err := clientset.StorageV1().CSINodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("checkCSINodeDriverPresent: failed to get CSINode for %s: %w", nodeName, err)
+	}
+
+	for _, driver := range csiNode.Spec.Drivers {
+		if driver.Name == driverName {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("checkCSINodeDriverPresent: driver not found on node %s", nodeName)
+}
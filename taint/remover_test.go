@@ -0,0 +1,95 @@
+package taint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRemoverWithCSINodeDriverPresent(t *testing.T) {
+	driverName := "fake.csi.driver.io"
+	nodeName := "node"
+	taintKey := driverName + AgentNotReadyNodeTaintKeySuffix
+
+	client := fake.NewSimpleClientset(
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Spec:       v1.NodeSpec{Taints: []v1.Taint{{Key: taintKey}}},
+		},
+		&storagev1.CSINode{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Spec: storagev1.CSINodeSpec{
+				Drivers: []storagev1.CSINodeDriver{{Name: driverName}},
+			},
+		},
+	)
+
+	remover := NewRemover(client, nodeName, driverName, WithCSINodeDriverPresent())
+	require.NoError(t, remover.Remove(context.Background()))
+
+	node, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, node.Spec.Taints)
+}
+
+func TestRemoverWithCustomCheck(t *testing.T) {
+	driverName := "fake.csi.driver.io"
+	nodeName := "node"
+	taintKey := driverName + AgentNotReadyNodeTaintKeySuffix
+
+	client := fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+		Spec:       v1.NodeSpec{Taints: []v1.Taint{{Key: taintKey}}},
+	})
+
+	ready := false
+	remover := NewRemover(client, nodeName, driverName, WithCustomCheck(func(ctx context.Context) error {
+		if !ready {
+			return errors.New("not ready yet")
+		}
+		return nil
+	}))
+
+	require.Error(t, remover.Remove(context.Background()))
+	node, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, node.Spec.Taints)
+
+	ready = true
+	require.NoError(t, remover.Remove(context.Background()))
+	node, err = client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, node.Spec.Taints)
+}
+
+func TestRemoverChecksAllRunInOrder(t *testing.T) {
+	driverName := "fake.csi.driver.io"
+	nodeName := "node"
+
+	client := fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+	})
+
+	var order []string
+	remover := NewRemover(client, nodeName, driverName,
+		WithCustomCheck(func(ctx context.Context) error {
+			order = append(order, "first")
+			return nil
+		}),
+		WithCustomCheck(func(ctx context.Context) error {
+			order = append(order, "second")
+			return fmt.Errorf("second check failed")
+		}),
+	)
+
+	require.Error(t, remover.Remove(context.Background()))
+	assert.Equal(t, []string{"first", "second"}, order)
+}
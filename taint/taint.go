@@ -3,62 +3,73 @@ package taint
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/klog/v2"
-	"k8s.io/kubernetes/pkg/controller"
-	taintutils "k8s.io/kubernetes/pkg/util/taints"
 )
 
 const AgentNotReadyNodeTaintKeySuffix = "/agent-not-ready"
 
-// RemoveTaintInBackground is a goroutine that retries removeNotReadyTaint with exponential backoff
-func RemoveTaintInBackground(ctx context.Context, k8sClient kubernetes.Interface, nodeName, driverName string, backoff wait.Backoff) {
+// RemoveTaintInBackground is a goroutine that retries removing the driverName/agent-not-ready
+// taint with exponential backoff, gated on the default readiness check (WithAllocatableCheck).
+// recorder, if non-nil, receives a Normal "TaintRemoved" event on success and a Warning
+// "TaintRemovalFailed" event on each failed attempt. registerer, if non-nil, receives the
+// csi_taint_removal_attempts_total counter and csi_taint_removal_duration_seconds histogram,
+// registered lazily so multiple drivers sharing a process don't collide. Callers that need a
+// different readiness signal should build their own Remover via NewRemover and drive it the same
+// way this function drives the default one.
+func RemoveTaintInBackground(ctx context.Context, k8sClient kubernetes.Interface, nodeName, driverName string, recorder events.EventRecorder, registerer prometheus.Registerer, backoff wait.Backoff) {
+	metrics := registerMetrics(registerer)
+	remover := NewRemover(k8sClient, nodeName, driverName)
+	removeTaintInBackground(ctx, remover, driverName, recorder, metrics, backoff)
+}
+
+func removeTaintInBackground(ctx context.Context, remover *Remover, driverName string, recorder events.EventRecorder, metrics *removalMetrics, backoff wait.Backoff) {
 	logger := klog.FromContext(ctx)
+	start := time.Now()
+
+	emitEvent := func(eventtype, reason, note string, args ...interface{}) {
+		if recorder == nil {
+			return
+		}
+		node, err := remover.client.CoreV1().Nodes().Get(ctx, remover.nodeName, metav1.GetOptions{})
+		if err != nil {
+			logger.Error(err, "Unexpected failure getting node to record taint removal event")
+			return
+		}
+		recorder.Eventf(node, nil, eventtype, reason, "RemoveTaint", note, args...)
+	}
+
 	backoffErr := wait.ExponentialBackoff(backoff, func() (bool, error) {
-		err := removeNotReadyTaint(ctx, k8sClient, nodeName, driverName)
+		err := remover.Remove(ctx)
 		if err != nil {
 			logger.Error(err, "Unexpected failure when attempting to remove node taint(s)")
+			if metrics != nil {
+				metrics.attemptsTotal.WithLabelValues(driverName, "failure").Inc()
+			}
+			emitEvent(v1.EventTypeWarning, "TaintRemovalFailed", "Failed to remove agent-not-ready taint: %s", err)
 			return false, nil
 		}
+		if metrics != nil {
+			metrics.attemptsTotal.WithLabelValues(driverName, "success").Inc()
+		}
 		return true, nil
 	})
+	if metrics != nil {
+		metrics.durationSeconds.WithLabelValues(driverName).Observe(time.Since(start).Seconds())
+	}
 
 	if backoffErr != nil {
 		logger.Error(backoffErr, "Retries exhausted, giving up attempting to remove node taint(s)")
+		return
 	}
-}
-
-// removeNotReadyTaint removes the taint driverName/agent-not-ready from the local node
-// This taint can be optionally applied by users to prevent startup race conditions such as
-// https://github.com/kubernetes/kubernetes/issues/95911
-func removeNotReadyTaint(ctx context.Context, clientset kubernetes.Interface, nodeName, driverName string) error {
-	logger := klog.FromContext(ctx)
-	node, err := clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
-	if err != nil {
-		return err
-	}
-
-	if err := checkAllocatable(ctx, clientset, nodeName, driverName); err != nil {
-		return err
-	}
-
-	taintKeyToRemove := driverName + AgentNotReadyNodeTaintKeySuffix
-
-	logger.V(2).Info("removing taint", "key", taintKeyToRemove, "node", nodeName)
-
-	// We cannot use controller.RemoveTaintOffNode as it matches against effect as well
-	newTaints, _ := taintutils.DeleteTaintsByKey(node.Spec.Taints, taintKeyToRemove)
-	newNode := node.DeepCopy()
-	newNode.Spec.Taints = newTaints
-	err = controller.PatchNodeTaints(ctx, clientset, nodeName, node, newNode)
-
-	if err != nil {
-		return err
-	}
-	logger.V(2).Info("removed taint successfully", "key", taintKeyToRemove, "node", nodeName)
-	return nil
+	emitEvent(v1.EventTypeNormal, "TaintRemoved", "Removed agent-not-ready taint")
 }
 
 func checkAllocatable(ctx context.Context, clientset kubernetes.Interface, nodeName, driverName string) error {
@@ -58,7 +58,7 @@ func TestRemoveTaint(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	RemoveTaintInBackground(context.TODO(), client, nodeName, driverName, taintRemovalBackoff)
+	RemoveTaintInBackground(context.TODO(), client, nodeName, driverName, nil, nil, taintRemovalBackoff)
 
 	for event := range w.ResultChan() {
 		n, ok := event.Object.(*v1.Node)
@@ -0,0 +1,119 @@
+package taint
+
+import (
+	"context"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// watchPollInterval is how often WatchAndRestoreTaintOnDisconnect reconciles on its own. Readiness
+// is only read during a reconcile, so a readiness change is acted on at the next poll or the next
+// observed node change, whichever comes first.
+const watchPollInterval = 30 * time.Second
+
+// Readiness reports whether the driver currently considers itself ready to serve traffic on this
+// node. WatchAndRestoreTaintOnDisconnect polls it to decide whether the agent-not-ready taint
+// should be re-applied or removed.
+type Readiness func() bool
+
+// WatchAndRestoreTaintOnDisconnect keeps watching nodeName after the driverName/agent-not-ready
+// taint has been removed, and re-applies it with effect NoSchedule whenever ready reports the
+// driver has become unready - for example because its gRPC socket disappeared or its CSINode
+// Allocatable was cleared between NodeGetInfo calls. It removes the taint again once ready
+// reports true. This is the compensation pattern used by the EFS CSI driver: once the initial
+// taint is gone, nothing re-protects pod scheduling if the driver later crashes, so this function
+// must keep running for the lifetime of the driver, unlike the one-shot RemoveTaintInBackground.
+func WatchAndRestoreTaintOnDisconnect(ctx context.Context, client kubernetes.Interface, nodeName, driverName string, ready Readiness, backoff wait.Backoff) {
+	logger := klog.FromContext(ctx)
+	taint := v1.Taint{Key: driverName + AgentNotReadyNodeTaintKeySuffix, Effect: v1.TaintEffectNoSchedule}
+	manager := NewTaintManager(client, nodeName)
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", nodeName).String()
+
+	reconcile := func() {
+		apply := !ready()
+
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			logger.Error(err, "Unexpected failure getting node to reconcile agent-not-ready taint")
+			return
+		}
+		if taintExistsByKeyEffect(node.Spec.Taints, taint) == apply {
+			// Already in the desired state; nothing to patch.
+			return
+		}
+
+		backoffErr := wait.ExponentialBackoff(backoff, func() (bool, error) {
+			var err error
+			if apply {
+				err = manager.AddTaints(ctx, []v1.Taint{taint})
+			} else {
+				err = manager.RemoveTaints(ctx, []v1.Taint{taint})
+			}
+			if err != nil {
+				logger.Error(err, "Unexpected failure reconciling agent-not-ready taint", "apply", apply)
+				return false, nil
+			}
+			return true, nil
+		})
+		if backoffErr != nil {
+			logger.Error(backoffErr, "Retries exhausted reconciling agent-not-ready taint", "apply", apply)
+		}
+	}
+
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return client.CoreV1().Nodes().List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return client.CoreV1().Nodes().Watch(ctx, options)
+		},
+	}
+
+	reconcileCh := make(chan struct{}, 1)
+	trigger := func() {
+		select {
+		case reconcileCh <- struct{}{}:
+		default:
+		}
+	}
+
+	// The informer lets us notice a node taint that was mutated out from under us (for example by
+	// another controller) so we can re-reconcile immediately instead of waiting for the next poll.
+	// The handler only triggers a reconcile on the informer's own goroutine; the actual Get/Patch
+	// work, including its backoff retry loop, runs from the select loop below.
+	_, informer := cache.NewInformer(lw, &v1.Node{}, 0, cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) { trigger() },
+	})
+
+	go informer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		logger.Error(nil, "failed to sync node informer", "node", nodeName)
+		return
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	trigger()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			trigger()
+		case <-reconcileCh:
+			reconcile()
+		}
+	}
+}
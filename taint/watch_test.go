@@ -0,0 +1,69 @@
+package taint
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	k8stesting "k8s.io/client-go/testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWatchAndRestoreTaintOnDisconnect(t *testing.T) {
+	driverName := "fake.csi.driver.io"
+	nodeName := "node"
+	taintKey := driverName + AgentNotReadyNodeTaintKeySuffix
+
+	client := fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+	})
+
+	fakeWatch := watch.NewFake()
+	client.PrependWatchReactor("nodes", k8stesting.DefaultWatchReactor(fakeWatch, nil))
+
+	var isReady atomic.Bool
+	isReady.Store(true)
+	ready := func() bool { return isReady.Load() }
+
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 5}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go WatchAndRestoreTaintOnDisconnect(ctx, client, nodeName, driverName, ready, backoff)
+
+	require.Eventually(t, func() bool {
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		require.NoError(t, err)
+		return !taintExistsByKeyEffect(node.Spec.Taints, v1.Taint{Key: taintKey, Effect: v1.TaintEffectNoSchedule})
+	}, time.Second, 10*time.Millisecond, "taint should not be present while ready")
+
+	// Driver goes unready: the taint should be re-applied.
+	isReady.Store(false)
+	fakeWatch.Modify(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})
+
+	require.Eventually(t, func() bool {
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		require.NoError(t, err)
+		return taintExistsByKeyEffect(node.Spec.Taints, v1.Taint{Key: taintKey, Effect: v1.TaintEffectNoSchedule})
+	}, time.Second, 10*time.Millisecond, "taint should be restored once the driver becomes unready")
+
+	// Driver becomes ready again: the taint should be removed.
+	isReady.Store(true)
+	fakeWatch.Modify(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})
+
+	require.Eventually(t, func() bool {
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		require.NoError(t, err)
+		return !taintExistsByKeyEffect(node.Spec.Taints, v1.Taint{Key: taintKey, Effect: v1.TaintEffectNoSchedule})
+	}, time.Second, 10*time.Millisecond, "taint should be removed once the driver is ready again")
+
+	assert.True(t, isReady.Load())
+}